@@ -17,32 +17,232 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"net"
 	"net/http"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/any"
 
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	streampb "github.com/dapr/dapr/tests/apps/resiliencyapp/proto"
 
 	"github.com/gorilla/mux"
 	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	channelzservice "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
 	pb "google.golang.org/grpc/examples/helloworld/helloworld"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 const (
-	appPort = 3000
+	appPort     = 3000
+	appGRPCPort = 3001
 )
 
-type FailureMessage struct {
-	ID              string         `json:"id"`
-	MaxFailureCount *int           `json:"maxFailureCount,omitempty"`
-	Timeout         *time.Duration `json:"timeout,omitempty"`
+// FaultOutcome is one weighted possibility for how a call under a FaultPolicy
+// resolves: a status code, an added delay, a clean success, or (for
+// streaming handlers) an abort of the in-flight stream. Exactly one of
+// Code/Delay/Success/AbortStream should be set; Weight is relative to the
+// other outcomes in the same FaultPolicy.
+type FaultOutcome struct {
+	Code        *int           `json:"code,omitempty"`
+	Delay       *time.Duration `json:"delay,omitempty"`
+	Success     bool           `json:"success,omitempty"`
+	AbortStream bool           `json:"abortStream,omitempty"`
+	Weight      float64        `json:"weight"`
+}
+
+// LatencyDistribution adds response latency on top of whatever a FaultPolicy
+// decides, independent of which FaultOutcome was picked.
+type LatencyDistribution struct {
+	// Type is one of "constant", "uniform", or "exponential".
+	Type string `json:"type"`
+	// Mean is used by the "constant" and "exponential" distributions.
+	Mean time.Duration `json:"mean,omitempty"`
+	// Min/Max bound the "uniform" distribution.
+	Min time.Duration `json:"min,omitempty"`
+	Max time.Duration `json:"max,omitempty"`
+	// Jitter adds up to +/- this much additional randomness to any of the
+	// distributions above.
+	Jitter time.Duration `json:"jitter,omitempty"`
+}
+
+// FaultPolicy is the fault-injection language shared by the binding, pubsub,
+// and service-invocation resiliency handlers. Outcomes are picked by a
+// seeded PRNG keyed by ID so the same ID always replays the same sequence of
+// decisions across a test run.
+type FaultPolicy struct {
+	ID         string               `json:"id"`
+	Outcomes   []FaultOutcome       `json:"outcomes,omitempty"`
+	Latency    *LatencyDistribution `json:"latency,omitempty"`
+	ResetAfter *time.Duration       `json:"resetAfter,omitempty"`
+}
+
+// FaultDecision is what a faultEvaluator decided for a single call.
+type FaultDecision struct {
+	Code        *int
+	Delay       time.Duration
+	AbortStream bool
+}
+
+// faultEvaluator picks FaultOutcomes for a FaultPolicy using a PRNG seeded
+// from the policy's ID, so scenarios replay deterministically. It is shared
+// across the binding, pubsub, and service-invocation resiliency handlers so
+// a given ID behaves identically no matter which of them is under test.
+type faultEvaluator struct {
+	mu       sync.Mutex
+	rngs     map[string]*rand.Rand
+	lastSeen map[string]time.Time
+}
+
+func newFaultEvaluator() *faultEvaluator {
+	return &faultEvaluator{
+		rngs:     map[string]*rand.Rand{},
+		lastSeen: map[string]time.Time{},
+	}
+}
+
+func seedFromID(id string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	return int64(h.Sum64())
+}
+
+// rngFor returns the persistent PRNG for id, resetting it and its recorded
+// call count if ResetAfter has elapsed since the last call, simulating
+// half-open circuit recovery.
+func (f *faultEvaluator) rngFor(policy *FaultPolicy) *rand.Rand {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if policy.ResetAfter != nil {
+		if last, ok := f.lastSeen[policy.ID]; ok && now.Sub(last) > *policy.ResetAfter {
+			delete(f.rngs, policy.ID)
+			resetCallCount(policy.ID)
+		}
+	}
+	f.lastSeen[policy.ID] = now
+
+	rng, ok := f.rngs[policy.ID]
+	if !ok {
+		rng = rand.New(rand.NewSource(seedFromID(policy.ID)))
+		f.rngs[policy.ID] = rng
+	}
+	return rng
+}
+
+func pickOutcome(rng *rand.Rand, outcomes []FaultOutcome) FaultOutcome {
+	var total float64
+	for _, o := range outcomes {
+		total += o.Weight
+	}
+	if total <= 0 {
+		return FaultOutcome{Success: true}
+	}
+
+	r := rng.Float64() * total
+	var cumulative float64
+	for _, o := range outcomes {
+		cumulative += o.Weight
+		if r < cumulative {
+			return o
+		}
+	}
+	return outcomes[len(outcomes)-1]
+}
+
+func sampleLatency(rng *rand.Rand, dist *LatencyDistribution) time.Duration {
+	var d time.Duration
+	switch dist.Type {
+	case "uniform":
+		span := dist.Max - dist.Min
+		if span > 0 {
+			d = dist.Min + time.Duration(rng.Int63n(int64(span)+1))
+		} else {
+			d = dist.Min
+		}
+	case "exponential":
+		d = time.Duration(rng.ExpFloat64() * float64(dist.Mean))
+	default: // "constant"
+		d = dist.Mean
+	}
+
+	if dist.Jitter > 0 {
+		d += time.Duration(rng.Int63n(int64(dist.Jitter)*2+1)) - dist.Jitter
+		if d < 0 {
+			d = 0
+		}
+	}
+	return d
+}
+
+// Evaluate decides the outcome of a single call under policy, drawing from
+// the policy's PRNG (one draw to pick an outcome when the outcomes carry a
+// positive total weight, plus up to two more when Latency is set) so
+// repeated calls with the same ID replay the same sequence of decisions.
+func (f *faultEvaluator) Evaluate(policy *FaultPolicy) FaultDecision {
+	rng := f.rngFor(policy)
+
+	decision := FaultDecision{}
+	if len(policy.Outcomes) > 0 {
+		outcome := pickOutcome(rng, policy.Outcomes)
+		decision.Code = outcome.Code
+		decision.AbortStream = outcome.AbortStream
+		if outcome.Delay != nil {
+			decision.Delay = *outcome.Delay
+		}
+	}
+
+	if policy.Latency != nil {
+		decision.Delay += sampleLatency(rng, policy.Latency)
+	}
+
+	return decision
+}
+
+// applyFaultDecision sleeps for the decided delay and reports whether the
+// handler should stop and respond with a failure; it does not record the
+// call, which the handlers do with recordCall. When the decision aborts the
+// stream, it hijacks and closes the underlying connection instead of writing
+// a response, to emulate an abrupt disconnect rather than a clean error
+// code; if the connection cannot be hijacked it responds with 503.
+func applyFaultDecision(w http.ResponseWriter, policy *FaultPolicy, decision FaultDecision) (failed bool) {
+	if decision.Delay > 0 {
+		time.Sleep(decision.Delay)
+	}
+
+	if decision.AbortStream {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	}
+
+	if decision.Code != nil && *decision.Code >= 300 {
+		w.WriteHeader(*decision.Code)
+		return true
+	}
+
+	return false
 }
 
 type CallRecord struct {
@@ -50,15 +250,226 @@ type CallRecord struct {
 	TimeSeen time.Time
 }
 
+// appendCallRecord appends a new CallRecord for key to callTracking and
+// returns its call count, one more than key's previous record (or 0 for the
+// first).
+func appendCallRecord(key string) int {
+	callTrackingMu.Lock()
+	defer callTrackingMu.Unlock()
+
+	count := 0
+	if records, ok := callTracking[key]; ok {
+		count = records[len(records)-1].Count + 1
+	}
+	callTracking[key] = append(callTracking[key], CallRecord{Count: count, TimeSeen: time.Now()})
+	return count
+}
+
+// resetCallCount clears callTracking for id, so a faultEvaluator's
+// ResetAfter recovery is reflected in reported call counts and not just in
+// the replayed PRNG sequence.
+func resetCallCount(id string) {
+	callTrackingMu.Lock()
+	defer callTrackingMu.Unlock()
+	delete(callTracking, id)
+}
+
+// recordCall tracks a call against id in callTracking and returns its call
+// count, shared by the binding, pubsub, and service-invocation handlers.
+func recordCall(id string) int {
+	return appendCallRecord(id)
+}
+
 type PubsubResponse struct {
 	// Status field for proper handling of errors form pubsub
 	Status  string `json:"status,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
+// SetHealthMessage is the body accepted by /tests/setHealth/{service}. Status
+// must be one of the grpc_health_v1 serving status names (SERVING,
+// NOT_SERVING, SERVICE_UNKNOWN). When FlapInterval is set, the service's
+// status alternates between SERVING and NOT_SERVING on that cadence until a
+// new /tests/setHealth call overrides it.
+type SetHealthMessage struct {
+	Status       string         `json:"status"`
+	FlapInterval *time.Duration `json:"flapInterval,omitempty"`
+}
+
+// serviceHealth holds the programmable health state for a single service
+// name reported by the app's grpc.health.v1 Health server.
+type serviceHealth struct {
+	mu           sync.Mutex
+	status       healthpb.HealthCheckResponse_ServingStatus
+	flapInterval time.Duration
+	flapStop     chan struct{}
+}
+
+// healthServer implements grpc_health_v1.HealthServer with per-service state
+// that tests drive through /tests/setHealth/{service}.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	mu       sync.Mutex
+	services map[string]*serviceHealth
+}
+
+func newHealthServer() *healthServer {
+	return &healthServer{services: map[string]*serviceHealth{}}
+}
+
+// getOrCreate returns the state for service, registering it (defaulted to
+// SERVING) if this is the first time it has been programmed. Only
+// setStatus should call this; Check/Watch use lookup so an un-programmed
+// service is correctly reported as unknown rather than implicitly healthy.
+func (h *healthServer) getOrCreate(service string) *serviceHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.services[service]
+	if !ok {
+		s = &serviceHealth{status: healthpb.HealthCheckResponse_SERVING}
+		h.services[service] = s
+	}
+	return s
+}
+
+func (h *healthServer) lookup(service string) (*serviceHealth, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.services[service]
+	return s, ok
+}
+
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s, ok := h.lookup(req.Service)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &healthpb.HealthCheckResponse{Status: s.status}, nil
+}
+
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	var last healthpb.HealthCheckResponse_ServingStatus = -1
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if s, ok := h.lookup(req.Service); ok {
+			s.mu.Lock()
+			current = s.status
+			s.mu.Unlock()
+		}
+
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// recordHealthTransition tracks a health status change in callTracking so
+// tests can assert on backoff behavior using the same CallRecord shape used
+// for binding/pubsub/invocation calls.
+func recordHealthTransition(service string) {
+	callTrackingMu.Lock()
+	defer callTrackingMu.Unlock()
+
+	callCount := 0
+	if records, ok := callTracking[service]; ok {
+		callCount = records[len(records)-1].Count + 1
+	}
+	callTracking[service] = append(callTracking[service], CallRecord{Count: callCount, TimeSeen: time.Now()})
+}
+
+func toggleServingStatus(status healthpb.HealthCheckResponse_ServingStatus) healthpb.HealthCheckResponse_ServingStatus {
+	if status == healthpb.HealthCheckResponse_SERVING {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// setStatus applies a new status/flap configuration to a service, stopping
+// any previously running flap loop and starting a new one if requested.
+func (h *healthServer) setStatus(service string, status healthpb.HealthCheckResponse_ServingStatus, flapInterval time.Duration) {
+	s := h.getOrCreate(service)
+
+	s.mu.Lock()
+	if s.flapStop != nil {
+		close(s.flapStop)
+		s.flapStop = nil
+	}
+	s.status = status
+	s.flapInterval = flapInterval
+	recordHealthTransition(service)
+
+	var stop chan struct{}
+	if flapInterval > 0 {
+		stop = make(chan struct{})
+		s.flapStop = stop
+	}
+	s.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(flapInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				s.mu.Lock()
+				s.status = toggleServingStatus(s.status)
+				s.mu.Unlock()
+				recordHealthTransition(service)
+			}
+		}
+	}()
+}
+
+func parseServingStatus(status string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	switch status {
+	case "SERVING":
+		return healthpb.HealthCheckResponse_SERVING, nil
+	case "NOT_SERVING":
+		return healthpb.HealthCheckResponse_NOT_SERVING, nil
+	case "SERVICE_UNKNOWN":
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, nil
+	default:
+		return healthpb.HealthCheckResponse_UNKNOWN, fmt.Errorf("unknown serving status %q", status)
+	}
+}
+
 var (
-	daprClient   runtimev1pb.DaprClient
-	callTracking map[string][]CallRecord
+	daprClient runtimev1pb.DaprClient
+	healthSrv  *healthServer
+	faultEval  *faultEvaluator
+
+	// callTracking is written from request handlers, streaming goroutines, and
+	// the health flap timer, and ranged over by TestGetCallCount, so every
+	// access must go through callTrackingMu.
+	callTrackingMu sync.Mutex
+	callTracking   map[string][]CallRecord
+
+	// grpcConn is the client connection to the dapr sidecar. It is guarded by
+	// grpcClientMu so /tests/killConnection can tear it down and redial it
+	// out from under any in-flight callers.
+	grpcClientMu sync.Mutex
+	grpcConn     *grpc.ClientConn
 )
 
 // Endpoint handling.
@@ -97,27 +508,20 @@ func resiliencyBindingHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var message FailureMessage
-	json.NewDecoder(r.Body).Decode(&message)
-
-	log.Printf("Binding received message %+v\n", message)
+	var policy FaultPolicy
+	json.NewDecoder(r.Body).Decode(&policy)
 
-	callCount := 0
-	if records, ok := callTracking[message.ID]; ok {
-		callCount = records[len(records)-1].Count + 1
-	}
+	log.Printf("Binding received message %+v\n", policy)
 
-	log.Printf("Seen %s %d times.", message.ID, callCount)
+	// Evaluate before recordCall: Evaluate's rngFor may reset this ID's call
+	// count on ResetAfter, and recording first would let that reset wipe the
+	// very call that triggered it.
+	decision := faultEval.Evaluate(&policy)
+	callCount := recordCall(policy.ID)
+	log.Printf("Seen %s %d times.", policy.ID, callCount)
 
-	callTracking[message.ID] = append(callTracking[message.ID], CallRecord{Count: callCount, TimeSeen: time.Now()})
-	if message.MaxFailureCount != nil && callCount < *message.MaxFailureCount {
-		if message.Timeout != nil {
-			// This request can still succeed if the resiliency policy timeout is longer than this sleep.
-			time.Sleep(*message.Timeout)
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	if applyFaultDecision(w, &policy, decision) {
+		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
@@ -148,26 +552,19 @@ func resiliencyPubsubHandler(w http.ResponseWriter, r *http.Request) {
 
 	rawData := rawBody["data"].(map[string]interface{})
 	rawDataBytes, _ := json.Marshal(rawData)
-	var message FailureMessage
-	json.Unmarshal(rawDataBytes, &message)
-	log.Printf("Pubsub received message %+v\n", message)
-
-	callCount := 0
-	if records, ok := callTracking[message.ID]; ok {
-		callCount = records[len(records)-1].Count + 1
-	}
-
-	log.Printf("Seen %s %d times.", message.ID, callCount)
-
-	callTracking[message.ID] = append(callTracking[message.ID], CallRecord{Count: callCount, TimeSeen: time.Now()})
-	if message.MaxFailureCount != nil && callCount < *message.MaxFailureCount {
-		if message.Timeout != nil {
-			// This request can still succeed if the resiliency policy timeout is longer than this sleep.
-			time.Sleep(*message.Timeout)
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	var policy FaultPolicy
+	json.Unmarshal(rawDataBytes, &policy)
+	log.Printf("Pubsub received message %+v\n", policy)
+
+	// Evaluate before recordCall: Evaluate's rngFor may reset this ID's call
+	// count on ResetAfter, and recording first would let that reset wipe the
+	// very call that triggered it.
+	decision := faultEval.Evaluate(&policy)
+	callCount := recordCall(policy.ID)
+	log.Printf("Seen %s %d times.", policy.ID, callCount)
+
+	if applyFaultDecision(w, &policy, decision) {
+		return
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(PubsubResponse{
@@ -177,44 +574,317 @@ func resiliencyPubsubHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func resiliencyServiceInvocationHandler(w http.ResponseWriter, r *http.Request) {
-	var message FailureMessage
-	json.NewDecoder(r.Body).Decode(&message)
+	var policy FaultPolicy
+	json.NewDecoder(r.Body).Decode(&policy)
 
-	log.Printf("Http invocation received message %+v\n", message)
+	log.Printf("Http invocation received message %+v\n", policy)
 
-	callCount := 0
-	if records, ok := callTracking[message.ID]; ok {
-		callCount = records[len(records)-1].Count + 1
+	// Evaluate before recordCall: Evaluate's rngFor may reset this ID's call
+	// count on ResetAfter, and recording first would let that reset wipe the
+	// very call that triggered it.
+	decision := faultEval.Evaluate(&policy)
+	callCount := recordCall(policy.ID)
+	log.Printf("Seen %s %d times.", policy.ID, callCount)
+
+	if applyFaultDecision(w, &policy, decision) {
+		return
 	}
+	w.WriteHeader(http.StatusOK)
+}
 
-	log.Printf("Seen %s %d times.", message.ID, callCount)
+// resiliencyHealthHandler is driven by /tests/setHealth/{service} and
+// programs the serving status the app's grpc.health.v1 Health server reports
+// for that service, optionally flapping it on an interval.
+func resiliencyHealthHandler(w http.ResponseWriter, r *http.Request) {
+	service := mux.Vars(r)["service"]
 
-	callTracking[message.ID] = append(callTracking[message.ID], CallRecord{Count: callCount, TimeSeen: time.Now()})
-	if message.MaxFailureCount != nil && callCount < *message.MaxFailureCount {
-		if message.Timeout != nil {
-			// This request can still succeed if the resiliency policy timeout is longer than this sleep.
-			time.Sleep(*message.Timeout)
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	var message SetHealthMessage
+	if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+		log.Println("Could not parse message.")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status, err := parseServingStatus(message.Status)
+	if err != nil {
+		log.Printf("Invalid health status: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var flapInterval time.Duration
+	if message.FlapInterval != nil {
+		flapInterval = *message.FlapInterval
 	}
+
+	log.Printf("Setting health for %s to %s (flapInterval=%s)", service, message.Status, flapInterval)
+	healthSrv.setStatus(service, status, flapInterval)
+
 	w.WriteHeader(http.StatusOK)
 }
 
-// App startup/endpoint setup.
-func initGRPCClient() {
+// StreamFailureMessage configures how the app's streaming gRPC handlers
+// should behave, sent as the request body of /tests/invokeStream/{mode} and
+// carried as the StreamConfig on the first message of the stream itself.
+type StreamFailureMessage struct {
+	ID                string        `json:"id"`
+	FailAfterMessages int           `json:"failAfterMessages,omitempty"`
+	FailWithCode      codes.Code    `json:"failWithCode,omitempty"`
+	HalfCloseDelay    time.Duration `json:"halfCloseDelay,omitempty"`
+	PerMessageTimeout time.Duration `json:"perMessageTimeout,omitempty"`
+	// FaultPolicy, if set, is additionally evaluated against the shared
+	// faultEval for every message on the stream, keyed by ID, so the same ID
+	// replays the same decisions whether it's driving this stream or a
+	// unary binding/pubsub/service-invocation call.
+	FaultPolicy *FaultPolicy `json:"faultPolicy,omitempty"`
+}
+
+// streamMessageCount is how many messages the driver-side of a stream test
+// sends/expects when acting as the streaming party (client-streaming input,
+// server-streaming output).
+const streamMessageCount = 5
+
+// recordStreamMessage tracks a streamed message in callTracking keyed by
+// ID + sequence number, returning how many times that exact sequence number
+// had been seen before this call (0 the first time) so tests can assert on
+// per-message delivery and retries.
+func recordStreamMessage(id string, seq int32) int {
+	return appendCallRecord(fmt.Sprintf("%s-%d", id, seq))
+}
+
+// streamFailureCode returns the status code a stream handler should fail
+// with once cfg's FailAfterMessages threshold is crossed. The proto default
+// of 0 for fail_with_code is codes.OK, which would make status.Error return
+// nil and let the stream end as a clean success instead of injecting the
+// configured failure, so an unset FailWithCode falls back to
+// codes.Unavailable.
+func streamFailureCode(cfg *streampb.StreamConfig) codes.Code {
+	if c := cfg.GetFailWithCode(); c != 0 {
+		return codes.Code(c)
+	}
+	return codes.Unavailable
+}
+
+// evaluateStreamFault runs cfg's optional FaultPolicy (if any) through the
+// same faultEval used by the unary binding/pubsub/service-invocation
+// handlers, keyed by the same ID, so a stream and a unary call sharing an ID
+// replay the same sequence of decisions. FaultOutcome.Code is HTTP-specific
+// and is not honored here; only Delay and AbortStream apply to streaming
+// calls. ok is false when cfg carries no FaultPolicy.
+func evaluateStreamFault(cfg *streampb.StreamConfig) (decision FaultDecision, ok bool) {
+	raw := cfg.GetFaultPolicyJson()
+	if raw == "" {
+		return FaultDecision{}, false
+	}
+	var policy FaultPolicy
+	if err := json.Unmarshal([]byte(raw), &policy); err != nil {
+		log.Printf("invalid fault policy on stream config: %v", err)
+		return FaultDecision{}, false
+	}
+	return faultEval.Evaluate(&policy), true
+}
+
+// resiliencyStreamServer implements streampb.StreamTestServer, honoring the
+// FailAfterMessages/FailWithCode/HalfCloseDelay/PerMessageTimeout knobs
+// carried on the first StreamMessage of each call.
+type resiliencyStreamServer struct {
+	streampb.UnimplementedStreamTestServer
+}
+
+// ServerStream pushes streamMessageCount messages back to the caller,
+// failing with FailWithCode once FailAfterMessages have been sent.
+func (s *resiliencyStreamServer) ServerStream(in *streampb.StreamMessage, stream streampb.StreamTest_ServerStreamServer) error {
+	cfg := in.GetConfig()
+	id := cfg.GetId()
+
+	for seq := 0; seq < streamMessageCount; seq++ {
+		if decision, ok := evaluateStreamFault(cfg); ok {
+			if decision.Delay > 0 {
+				time.Sleep(decision.Delay)
+			}
+			if decision.AbortStream {
+				return status.Error(codes.Aborted, "induced stream abort")
+			}
+		}
+
+		if cfg.GetPerMessageTimeoutMs() > 0 {
+			time.Sleep(time.Duration(cfg.GetPerMessageTimeoutMs()) * time.Millisecond)
+		}
+		if cfg.GetFailAfterMessages() > 0 && int32(seq) >= cfg.GetFailAfterMessages() {
+			return status.Error(streamFailureCode(cfg), "induced stream failure")
+		}
+
+		if err := stream.Send(&streampb.StreamAck{Id: id, Seq: int32(seq), Status: "OK"}); err != nil {
+			return err
+		}
+		// Only record a message once it's actually been sent: a seq that
+		// fails the FailAfterMessages check above is never delivered, so it
+		// must not be counted as seen.
+		recordStreamMessage(id, int32(seq))
+	}
+	return nil
+}
+
+// ClientStream consumes messages from the caller until it half-closes,
+// failing with FailWithCode once FailAfterMessages have been received.
+func (s *resiliencyStreamServer) ClientStream(stream streampb.StreamTest_ClientStreamServer) error {
+	var cfg *streampb.StreamConfig
+	id := ""
+	received := 0
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if msg.GetConfig() != nil {
+			cfg = msg.GetConfig()
+			id = cfg.GetId()
+		}
+
+		if decision, ok := evaluateStreamFault(cfg); ok {
+			if decision.Delay > 0 {
+				time.Sleep(decision.Delay)
+			}
+			if decision.AbortStream {
+				return status.Error(codes.Aborted, "induced stream abort")
+			}
+		}
+
+		recordStreamMessage(id, msg.GetSeq())
+		received++
+
+		if cfg.GetPerMessageTimeoutMs() > 0 {
+			time.Sleep(time.Duration(cfg.GetPerMessageTimeoutMs()) * time.Millisecond)
+		}
+		if cfg.GetFailAfterMessages() > 0 && int32(received) >= cfg.GetFailAfterMessages() {
+			return status.Error(streamFailureCode(cfg), "induced stream failure")
+		}
+	}
+
+	if cfg.GetHalfCloseDelayMs() > 0 {
+		time.Sleep(time.Duration(cfg.GetHalfCloseDelayMs()) * time.Millisecond)
+	}
+
+	return stream.SendAndClose(&streampb.StreamAck{Id: id, Seq: int32(received), Status: "OK"})
+}
+
+// BidiStream acks each message as it arrives, failing with FailWithCode once
+// FailAfterMessages have been exchanged in either direction.
+func (s *resiliencyStreamServer) BidiStream(stream streampb.StreamTest_BidiStreamServer) error {
+	var cfg *streampb.StreamConfig
+	id := ""
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			if cfg.GetHalfCloseDelayMs() > 0 {
+				time.Sleep(time.Duration(cfg.GetHalfCloseDelayMs()) * time.Millisecond)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.GetConfig() != nil {
+			cfg = msg.GetConfig()
+			id = cfg.GetId()
+		}
+
+		if decision, ok := evaluateStreamFault(cfg); ok {
+			if decision.Delay > 0 {
+				time.Sleep(decision.Delay)
+			}
+			if decision.AbortStream {
+				return status.Error(codes.Aborted, "induced stream abort")
+			}
+		}
+
+		recordStreamMessage(id, msg.GetSeq())
+
+		if cfg.GetPerMessageTimeoutMs() > 0 {
+			time.Sleep(time.Duration(cfg.GetPerMessageTimeoutMs()) * time.Millisecond)
+		}
+		if cfg.GetFailAfterMessages() > 0 && msg.GetSeq() >= cfg.GetFailAfterMessages() {
+			return status.Error(streamFailureCode(cfg), "induced stream failure")
+		}
+
+		if err := stream.Send(&streampb.StreamAck{Id: id, Seq: msg.GetSeq(), Status: "OK"}); err != nil {
+			return err
+		}
+	}
+}
+
+// keepaliveParamsFromEnv builds the client-side keepalive.ClientParameters
+// used when dialing the dapr sidecar, letting tests tune Time/Timeout/
+// PermitWithoutStream through the app's environment. Time defaults to 0
+// (pings disabled): a nonzero default that happens to beat the sidecar's
+// keepalive EnforcementPolicy.MinTime risks a GOAWAY too_many_pings that
+// drops the very connection these resiliency tests depend on, so tests that
+// want to exercise keepalive pings must opt in with DAPR_KEEPALIVE_TIME set
+// to a value at or above the sidecar's configured minimum. dialDaprClient
+// only applies these parameters when Time is nonzero, so Timeout and
+// PermitWithoutStream have no effect unless DAPR_KEEPALIVE_TIME is set.
+func keepaliveParamsFromEnv() keepalive.ClientParameters {
+	return keepalive.ClientParameters{
+		Time:                getEnvDuration("DAPR_KEEPALIVE_TIME", 0),
+		Timeout:             getEnvDuration("DAPR_KEEPALIVE_TIMEOUT", 20*time.Second),
+		PermitWithoutStream: getEnvBool("DAPR_KEEPALIVE_PERMIT_WITHOUT_STREAM", false),
+	}
+}
+
+func getEnvDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid duration for %s=%q, using default %s", name, v, def)
+		return def
+	}
+	return d
+}
+
+func getEnvBool(name string, def bool) bool {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("Invalid bool for %s=%q, using default %t", name, v, def)
+		return def
+	}
+	return b
+}
+
+// dialDaprClient dials the dapr sidecar's gRPC port, making up to 10
+// attempts 5 seconds apart, and returns the resulting connection; it panics
+// if the last attempt still fails.
+func dialDaprClient() *grpc.ClientConn {
 	url := fmt.Sprintf("localhost:%d", 50001)
 	log.Printf("Connecting to dapr using url %s", url)
-	var grpcConn *grpc.ClientConn
+
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	// grpc-go raises any keepalive Time below its 10s minimum (including 0)
+	// to 10s, so the option is only passed when pings are enabled.
+	if kp := keepaliveParamsFromEnv(); kp.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(kp))
+	}
+
+	var conn *grpc.ClientConn
 	for retries := 10; retries > 0; retries-- {
 		var err error
-		grpcConn, err = grpc.Dial(url, grpc.WithInsecure())
+		conn, err = grpc.Dial(url, opts...)
 		if err == nil {
 			break
 		}
 
-		if retries == 0 {
+		if retries == 1 {
 			log.Printf("Could not connect to dapr: %v", err)
 			log.Panic(err)
 		}
@@ -223,9 +893,58 @@ func initGRPCClient() {
 		time.Sleep(5 * time.Second)
 	}
 
+	return conn
+}
+
+// getDaprClient returns the current client for the dapr sidecar connection,
+// guarding the read with grpcClientMu since TestKillConnection swaps
+// daprClient/grpcConn out from under any in-flight callers.
+func getDaprClient() runtimev1pb.DaprClient {
+	grpcClientMu.Lock()
+	defer grpcClientMu.Unlock()
+	return daprClient
+}
+
+// App startup/endpoint setup.
+//
+// initGRPCClient dials the dapr sidecar. It does not separately register
+// the resulting ClientConn with channelz: grpc-go instruments every
+// ClientConn and Server in the process into the same process-global
+// channelz registry, so the channelzservice already registered on this
+// app's own gRPC server in initGRPCServer exposes this client connection's
+// channels, sockets, and keepalive activity too.
+func initGRPCClient() {
+	grpcClientMu.Lock()
+	defer grpcClientMu.Unlock()
+
+	grpcConn = dialDaprClient()
 	daprClient = runtimev1pb.NewDaprClient(grpcConn)
 }
 
+// initGRPCServer starts the app's own gRPC listener, exposing the
+// grpc.health.v1 Health service so resiliency scenarios can be driven
+// through the standard gRPC Health Checking Protocol, plus the channelz
+// service so tests can inspect socket/channel/keepalive state (including
+// the dapr client connection dialed in initGRPCClient, since channelz
+// registration is process-global rather than per-server).
+func initGRPCServer() {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", appGRPCPort))
+	if err != nil {
+		log.Fatalf("Could not listen on port %d: %v", appGRPCPort, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthSrv)
+	streampb.RegisterStreamTestServer(grpcServer, &resiliencyStreamServer{})
+	channelzservice.RegisterChannelzServiceToServer(grpcServer)
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Printf("grpc server error: %v", err)
+		}
+	}()
+}
+
 func newHTTPClient() *http.Client {
 	dialer := &net.Dialer{ //nolint:exhaustivestruct
 		Timeout: 5 * time.Second,
@@ -253,6 +972,7 @@ func appRouter() *mux.Router {
 	router.HandleFunc("/resiliencybinding", resiliencyBindingHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/resiliency-topic-http", resiliencyPubsubHandler).Methods("POST")
 	router.HandleFunc("/resiliencyInvocation", resiliencyServiceInvocationHandler).Methods("POST")
+	router.HandleFunc("/tests/setHealth/{service}", resiliencyHealthHandler).Methods("POST")
 
 	// Test functions.
 	router.HandleFunc("/tests/getCallCount", TestGetCallCount).Methods("GET")
@@ -260,6 +980,10 @@ func appRouter() *mux.Router {
 	router.HandleFunc("/tests/invokeBinding/{binding}", TestInvokeOutputBinding).Methods("POST")
 	router.HandleFunc("/tests/publishMessage/{pubsub}/{topic}", TestPublishMessage).Methods("POST")
 	router.HandleFunc("/tests/invokeService/{protocol}", TestInvokeService).Methods("POST")
+	router.HandleFunc("/tests/invokeHealth/{appID}/{service}", TestInvokeHealth).Methods("POST")
+	router.HandleFunc("/tests/invokeStream/{mode}", TestInvokeStream).Methods("POST")
+	router.HandleFunc("/tests/channelz/{kind}", TestChannelz).Methods("GET")
+	router.HandleFunc("/tests/killConnection", TestKillConnection).Methods("POST")
 
 	router.Use(mux.CORSMethodMiddleware(router))
 
@@ -269,7 +993,10 @@ func appRouter() *mux.Router {
 func main() {
 	log.Printf("Hello Dapr - listening on http://localhost:%d", appPort)
 	callTracking = map[string][]CallRecord{}
+	healthSrv = newHealthServer()
+	faultEval = newFaultEvaluator()
 	initGRPCClient()
+	initGRPCServer()
 
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", appPort), appRouter()))
 }
@@ -277,6 +1004,10 @@ func main() {
 // Test Functions.
 func TestGetCallCount(w http.ResponseWriter, r *http.Request) {
 	log.Println("Getting call counts")
+
+	callTrackingMu.Lock()
+	defer callTrackingMu.Unlock()
+
 	for key, val := range callTracking {
 		log.Printf("\t%s - Called %d times.\n", key, len(val))
 	}
@@ -303,7 +1034,7 @@ func TestGetCallCountGRPC(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	resp, err := daprClient.InvokeService(context.Background(), &req)
+	resp, err := getDaprClient().InvokeService(context.Background(), &req)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -315,7 +1046,7 @@ func TestInvokeOutputBinding(w http.ResponseWriter, r *http.Request) {
 	binding := mux.Vars(r)["binding"]
 	log.Printf("Making call to output binding %s.", binding)
 
-	var message FailureMessage
+	var message FaultPolicy
 	err := json.NewDecoder(r.Body).Decode(&message)
 	if err != nil {
 		log.Println("Could not parse message.")
@@ -330,7 +1061,7 @@ func TestInvokeOutputBinding(w http.ResponseWriter, r *http.Request) {
 		Data:      b,
 	}
 
-	_, err = daprClient.InvokeBinding(context.Background(), req)
+	_, err = getDaprClient().InvokeBinding(context.Background(), req)
 	if err != nil {
 		log.Printf("Error invoking binding: %s", err.Error())
 		w.WriteHeader(http.StatusInternalServerError)
@@ -341,7 +1072,7 @@ func TestPublishMessage(w http.ResponseWriter, r *http.Request) {
 	pubsub := mux.Vars(r)["pubsub"]
 	topic := mux.Vars(r)["topic"]
 
-	var message FailureMessage
+	var message FaultPolicy
 	err := json.NewDecoder(r.Body).Decode(&message)
 	if err != nil {
 		log.Println("Could not parse message.")
@@ -359,7 +1090,7 @@ func TestPublishMessage(w http.ResponseWriter, r *http.Request) {
 		DataContentType: "application/json",
 	}
 
-	_, err = daprClient.PublishEvent(context.Background(), req)
+	_, err = getDaprClient().PublishEvent(context.Background(), req)
 	if err != nil {
 		log.Printf("Error publishing event: %s", err.Error())
 		w.WriteHeader(http.StatusInternalServerError)
@@ -384,7 +1115,7 @@ func TestInvokeService(w http.ResponseWriter, r *http.Request) {
 		}
 		w.WriteHeader(resp.StatusCode)
 	} else if protocol == "grpc" {
-		var message FailureMessage
+		var message FaultPolicy
 		err := json.NewDecoder(r.Body).Decode(&message)
 		if err != nil {
 			log.Println("Could not parse message.")
@@ -403,14 +1134,14 @@ func TestInvokeService(w http.ResponseWriter, r *http.Request) {
 			},
 		}
 
-		_, err = daprClient.InvokeService(r.Context(), req)
+		_, err = getDaprClient().InvokeService(r.Context(), req)
 		if err != nil {
 			log.Printf("Failed to invoke service: %s", err.Error())
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	} else if protocol == "grpc_proxy" {
-		var message FailureMessage
+		var message FaultPolicy
 		err := json.NewDecoder(r.Body).Decode(&message)
 		if err != nil {
 			log.Println("Could not parse message.")
@@ -439,3 +1170,238 @@ func TestInvokeService(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+// TestInvokeHealth drives a Check call against appID's grpc.health.v1 Health
+// server through the dapr sidecar's gRPC proxy, so resiliency tests can
+// assert that Dapr retries/circuit-breaks/fails over correctly when the
+// callee reports transient unhealthiness. Pass this app's own Dapr app-id to
+// observe the health state programmed by /tests/setHealth/{service} on this
+// same instance; pass another app's id to exercise a real callee.
+func TestInvokeHealth(w http.ResponseWriter, r *http.Request) {
+	appID := mux.Vars(r)["appID"]
+	service := mux.Vars(r)["service"]
+	log.Printf("Invoking health check for %s/%s", appID, service)
+
+	conn, err := grpc.Dial("localhost:50001", grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Printf("did not connect: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(r.Context(), "dapr-app-id", appID)
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		log.Printf("health check failed: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("failed to check health: %s", err)))
+		return
+	}
+
+	b, _ := json.Marshal(resp)
+	w.Write(b)
+}
+
+// TestInvokeStream drives the StreamTest service through the dapr sidecar's
+// gRPC proxy in server-streaming, client-streaming, or bidi-streaming mode,
+// so resiliency tests can validate retry/timeout/circuit-breaker policies
+// against streaming calls.
+func TestInvokeStream(w http.ResponseWriter, r *http.Request) {
+	mode := mux.Vars(r)["mode"]
+
+	var message StreamFailureMessage
+	if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+		log.Println("Could not parse message.")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Invoking %s stream with %+v", mode, message)
+
+	conn, err := grpc.Dial("localhost:50001", grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Printf("did not connect: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+
+	client := streampb.NewStreamTestClient(conn)
+	ctx := metadata.AppendToOutgoingContext(r.Context(), "dapr-app-id", "resiliencyappgrpc")
+	cfg := &streampb.StreamConfig{
+		Id:                  message.ID,
+		FailAfterMessages:   int32(message.FailAfterMessages),
+		FailWithCode:        int32(message.FailWithCode),
+		HalfCloseDelayMs:    message.HalfCloseDelay.Milliseconds(),
+		PerMessageTimeoutMs: message.PerMessageTimeout.Milliseconds(),
+	}
+	if message.FaultPolicy != nil {
+		raw, err := json.Marshal(message.FaultPolicy)
+		if err != nil {
+			log.Printf("could not marshal fault policy: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		cfg.FaultPolicyJson = string(raw)
+	}
+
+	switch mode {
+	case "server":
+		err = invokeServerStream(ctx, client, cfg)
+	case "client":
+		err = invokeClientStream(ctx, client, cfg)
+	case "bidi":
+		err = invokeBidiStream(ctx, client, cfg)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		log.Printf("stream %s failed: %v", mode, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf("stream %s failed: %s", mode, err)))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func invokeServerStream(ctx context.Context, client streampb.StreamTestClient, cfg *streampb.StreamConfig) error {
+	stream, err := client.ServerStream(ctx, &streampb.StreamMessage{Config: cfg})
+	if err != nil {
+		return err
+	}
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func invokeClientStream(ctx context.Context, client streampb.StreamTestClient, cfg *streampb.StreamConfig) error {
+	stream, err := client.ClientStream(ctx)
+	if err != nil {
+		return err
+	}
+	for seq := 0; seq < streamMessageCount; seq++ {
+		msg := &streampb.StreamMessage{Seq: int32(seq)}
+		if seq == 0 {
+			msg.Config = cfg
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func invokeBidiStream(ctx context.Context, client streampb.StreamTestClient, cfg *streampb.StreamConfig) error {
+	stream, err := client.BidiStream(ctx)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				errCh <- nil
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	for seq := 0; seq < streamMessageCount; seq++ {
+		msg := &streampb.StreamMessage{Seq: int32(seq)}
+		if seq == 0 {
+			msg.Config = cfg
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	return <-errCh
+}
+
+// TestChannelz proxies channelz queries against the app's own gRPC server
+// back as JSON, so tests can assert on socket counts, keepalive pings, and
+// stream lifecycles during resiliency scenarios.
+func TestChannelz(w http.ResponseWriter, r *http.Request) {
+	kind := mux.Vars(r)["kind"]
+	log.Printf("Querying channelz for %s", kind)
+
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", appGRPCPort), grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Printf("did not connect: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+
+	client := channelzpb.NewChannelzClient(conn)
+	ctx := r.Context()
+
+	var resp interface{}
+	switch kind {
+	case "channels":
+		resp, err = client.GetTopChannels(ctx, &channelzpb.GetTopChannelsRequest{})
+	case "servers":
+		resp, err = client.GetServers(ctx, &channelzpb.GetServersRequest{})
+	case "sockets":
+		var servers *channelzpb.GetServersResponse
+		servers, err = client.GetServers(ctx, &channelzpb.GetServersRequest{})
+		if err == nil && len(servers.GetServer()) > 0 {
+			resp, err = client.GetServerSockets(ctx, &channelzpb.GetServerSocketsRequest{ServerId: servers.GetServer()[0].GetRef().GetServerId()})
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		log.Printf("channelz query %s failed: %v", kind, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Write(b)
+}
+
+// TestKillConnection forcibly closes the app's connection to the dapr
+// sidecar and reopens it, so tests can validate that Dapr's resiliency
+// policies correctly re-establish sidecar connectivity and that
+// keepalive-driven GOAWAYs are handled without dropping in-flight retries.
+func TestKillConnection(w http.ResponseWriter, r *http.Request) {
+	log.Println("Killing connection to dapr sidecar")
+
+	grpcClientMu.Lock()
+	if grpcConn != nil {
+		grpcConn.Close()
+	}
+	grpcClientMu.Unlock()
+
+	initGRPCClient()
+
+	w.WriteHeader(http.StatusOK)
+}